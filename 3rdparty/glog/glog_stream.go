@@ -0,0 +1,157 @@
+// Go support for leveled logs, analogous to https://code.google.com/p/google-glog/
+//
+// Copyright 2013 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Content-type/subsystem-scoped log streams: in addition to the aggregate
+// INFO/WARNING/ERROR tiers, a caller can open a named, independently rotated
+// stream (e.g. "ec", "copy"; see RegisterStream/GetStream) so a noisy subsystem doesn't
+// drown the primary INFO log. FATAL/ERROR lines written to a stream are
+// still mirrored into the aggregate error log.
+
+package glog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StreamOptions configures a single named log stream.
+type StreamOptions struct {
+	MaxSize  uint64        // rotate after this many bytes; 0 => use glog.MaxSize
+	MaxAge   time.Duration // rotate after this much wall-clock time; 0 => no age-based rotation
+	HeaderCB func() string // like FileHeaderCB, written at the top of every rotated file
+}
+
+// Stream is a named, rotated log stream with its own file and symlink
+// (e.g. "aistarget.ec" -> latest), independent of the INFO/WARNING/ERROR tiers.
+type Stream struct {
+	name string
+	opts StreamOptions
+
+	mu     sync.Mutex
+	file   *fileWithSize
+	openAt time.Time
+}
+
+type fileWithSize struct {
+	nbytes uint64
+	close  func() error
+	write  func(p []byte) (int, error)
+}
+
+var (
+	streamsMu sync.Mutex
+	streams   = make(map[string]*Stream)
+)
+
+// RegisterStream registers a new named stream. Like content-type and digest
+// registration elsewhere in aistore, all streams must be registered at
+// startup, before the stream is looked up via GetStream().
+func RegisterStream(name string, opts StreamOptions) error {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+	if _, ok := streams[name]; ok {
+		return fmt.Errorf("log stream %q is already registered", name)
+	}
+	streams[name] = &Stream{name: name, opts: opts}
+	return nil
+}
+
+// GetStream returns the stream registered as `name`, or (nil, false) if none
+// was registered - callers should fall back to the aggregate INFO log in that case.
+func GetStream(name string) (*Stream, bool) {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+	s, ok := streams[name]
+	return s, ok
+}
+
+func (s *Stream) maxSize() uint64 {
+	if s.opts.MaxSize > 0 {
+		return s.opts.MaxSize
+	}
+	return MaxSize
+}
+
+func (s *Stream) rotateLocked() error {
+	now := time.Now()
+	f, _, err := create(s.name, now)
+	if err != nil {
+		return err
+	}
+	if s.file != nil {
+		s.file.close()
+	}
+	s.file = &fileWithSize{write: f.Write, close: f.Close}
+	s.openAt = now
+	if s.opts.HeaderCB != nil {
+		header := s.opts.HeaderCB()
+		n, _ := f.WriteString(header)
+		s.file.nbytes += uint64(n)
+	}
+	return nil
+}
+
+func (s *Stream) needsRotateLocked() bool {
+	if s.file == nil {
+		return true
+	}
+	if s.file.nbytes >= s.maxSize() {
+		return true
+	}
+	if s.opts.MaxAge > 0 && time.Since(s.openAt) >= s.opts.MaxAge {
+		return true
+	}
+	return false
+}
+
+// Write implements io.Writer, rotating the underlying file as needed.
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.needsRotateLocked() {
+		if err := s.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := s.file.write(p)
+	s.file.nbytes += uint64(n)
+	return n, err
+}
+
+// Infof writes a line to the stream, falling back to the aggregate INFO log
+// if the stream failed to register (e.g. duplicate name).
+func (s *Stream) Infof(format string, args ...any) {
+	if s == nil {
+		Infof(format, args...)
+		return
+	}
+	fmt.Fprintf(s, format+"\n", args...)
+}
+
+// Errorf writes a line to the stream and mirrors it into the aggregate
+// error log, exactly like a direct glog.Errorf call would, so operators
+// tailing the primary ERROR log still see it. Falls back to the aggregate
+// log alone if the stream failed to register.
+func (s *Stream) Errorf(format string, args ...any) {
+	if s == nil {
+		Errorf(format, args...)
+		return
+	}
+	line := fmt.Sprintf(format+"\n", args...)
+	fmt.Fprint(s, line)
+	Errorf("[%s] %s", s.name, line)
+}
@@ -0,0 +1,178 @@
+// Package ocidistr implements the subset of the OCI Distribution Specification
+// (https://github.com/opencontainers/distribution-spec) v2 blob API that lets
+// an aistore target act as a registry-compatible blob store.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ocidistr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// digestGrammar is the OCI image-spec digest grammar (algorithm ":" encoded),
+// e.g. "sha256:<64 lowercase hex chars>". Rejecting anything else here - before
+// the digest is ever used as an object name / FQN path component - is what
+// keeps a malicious "../../etc/passwd"-style digest from reaching the
+// filesystem layer.
+var digestGrammar = regexp.MustCompile(`^[a-z0-9]+(?:[._+-][a-z0-9]+)*:[a-f0-9]+$`)
+
+// BlobHandler serves the OCI Distribution v2 blob endpoints for a single
+// repository (== bucket). Blobs live on disk under fs.OCIBlobType, resolved
+// via fs.CSM.Gen the same way workfiles below are - a *cluster.LOM is used
+// purely as the PartsFQN (bucket/mountpath) carrier here, not for its own
+// default "ob"-content-type identity, since a content-addressed blob isn't a
+// regular object.
+type BlobHandler struct {
+	T   cluster.Target
+	Bck *cmn.Bck
+}
+
+// digestObjName turns a "sha256:<hex>"-style digest into the object name
+// under which the blob is addressed. It enforces the OCI digest grammar
+// up front, since the result is about to become a path component (via
+// fs.OCIBlobContentResolver.GenUniqueFQN). The algorithm is not checked here:
+// Put hashes with fs.OCIBlobType's own algorithm (see fs.CSM.NewVerifier), so
+// a digest naming any other algorithm fails verification there.
+func digestObjName(digest string) (string, error) {
+	if !digestGrammar.MatchString(digest) {
+		return "", fmt.Errorf("invalid digest %q: does not match \"<algorithm>:<hex>\"", digest)
+	}
+	return digest, nil
+}
+
+// resolve allocates a LOM against digest's object name, solely to carry the
+// bucket/mountpath (fs.PartsFQN) that fs.CSM.Gen needs to produce the blob's
+// FQN below. Callers must cluster.FreeLOM the result.
+func (h *BlobHandler) resolve(digest string) (*cluster.LOM, error) {
+	objName, err := digestObjName(digest)
+	if err != nil {
+		return nil, err
+	}
+	lom := cluster.AllocLOM(objName)
+	if err := lom.Init(*h.Bck); err != nil {
+		cluster.FreeLOM(lom)
+		return nil, err
+	}
+	return lom, nil
+}
+
+// Head implements `HEAD /v2/<name>/blobs/<digest>`.
+func (h *BlobHandler) Head(w http.ResponseWriter, r *http.Request, digest string) {
+	lom, err := h.resolve(digest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+
+	fqn := fs.CSM.Gen(lom, fs.OCIBlobType, "")
+	fi, err := os.Stat(fqn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set(HeaderDockerContentDigest, digest)
+	w.Header().Set(HeaderContentLength, fmt.Sprintf("%d", fi.Size()))
+	w.WriteHeader(http.StatusOK)
+}
+
+// Get implements `GET /v2/<name>/blobs/<digest>`.
+func (h *BlobHandler) Get(w http.ResponseWriter, r *http.Request, digest string) {
+	lom, err := h.resolve(digest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+
+	fqn := fs.CSM.Gen(lom, fs.OCIBlobType, "")
+	f, err := os.Open(fqn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set(HeaderDockerContentDigest, digest)
+	w.Header().Set("Content-Type", mediaTypeOctetStream)
+	if fi, err := f.Stat(); err == nil {
+		w.Header().Set(HeaderContentLength, fmt.Sprintf("%d", fi.Size()))
+	}
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, f) //nolint:errcheck // best-effort once headers are already written
+}
+
+// Put implements the monolithic `PUT /v2/<name>/blobs/uploads/<uuid>?digest=<digest>`
+// that completes a two-step upload in a single call (no chunked state to track).
+//
+// The uploaded bytes are streamed straight to a workfile while being hashed
+// through the fs.Verifier registered for fs.OCIBlobType (see fs.CSM.NewVerifier);
+// only once the trailing digest matches what the client claimed is the
+// workfile atomically renamed onto the blob's content-addressed FQN. A blob
+// that doesn't hash to its own name is therefore rejected and never becomes
+// visible at that digest.
+func (h *BlobHandler) Put(w http.ResponseWriter, r *http.Request, digest string) {
+	lom, err := h.resolve(digest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+
+	verifier, ok := fs.CSM.NewVerifier(fs.OCIBlobType)
+	if !ok {
+		http.Error(w, fmt.Sprintf("%s: digest algorithm not registered", fs.OCIBlobType), http.StatusInternalServerError)
+		return
+	}
+
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, "oci-put")
+	wf, err := os.Create(workFQN)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = io.Copy(wf, io.TeeReader(r.Body, verifier))
+	if closeErr := wf.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(workFQN)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !verifier.Verified(digest) {
+		os.Remove(workFQN)
+		http.Error(w, fmt.Sprintf("uploaded bytes do not match claimed digest %q", digest), http.StatusBadRequest)
+		return
+	}
+
+	blobFQN := fs.CSM.Gen(lom, fs.OCIBlobType, "")
+	if err := os.Rename(workFQN, blobFQN); err != nil {
+		os.Remove(workFQN)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(HeaderDockerContentDigest, digest)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// StartUpload implements `POST /v2/<name>/blobs/uploads/`, the first step of
+// the two-step upload protocol. Since aistore does not (yet) support chunked
+// blob assembly, it hands back a session location that the client is expected
+// to immediately PUT the full blob to (monolithic-only upload).
+func (h *BlobHandler) StartUpload(w http.ResponseWriter, r *http.Request, name string) {
+	uuid := cos.GenUUID()
+	loc := fmt.Sprintf("%s/%s/%s/%s/%s", apiVersion, name, pathBlobs, pathUploads, uuid)
+	w.Header().Set(HeaderLocation, loc)
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,29 @@
+// Package ocidistr implements the subset of the OCI Distribution Specification
+// (https://github.com/opencontainers/distribution-spec) v2 blob API that lets
+// an aistore target act as a registry-compatible blob store.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ocidistr
+
+const (
+	apiVersion = "/v2"
+
+	// URL path elements, as defined by the distribution spec, e.g.:
+	//   GET /v2/<name>/blobs/<digest>
+	//   POST /v2/<name>/blobs/uploads/
+	//   PUT /v2/<name>/blobs/uploads/<uuid>?digest=<digest>
+	pathBlobs   = "blobs"
+	pathUploads = "uploads"
+
+	// Headers
+	HeaderDockerContentDigest = "Docker-Content-Digest"
+	HeaderRange               = "Range"
+	HeaderLocation            = "Location"
+	HeaderContentLength       = "Content-Length"
+
+	// URL parameters
+	URLParamDigest = "digest"
+
+	mediaTypeOctetStream = "application/octet-stream"
+)
@@ -0,0 +1,70 @@
+// Package ocidistr implements the subset of the OCI Distribution Specification
+// (https://github.com/opencontainers/distribution-spec) v2 blob API that lets
+// an aistore target act as a registry-compatible blob store.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ocidistr
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// MountPrefix is the path prefix Handler expects to be mounted at.
+const MountPrefix = apiVersion + "/"
+
+// BckResolver resolves a repository name (the distribution spec's <name>
+// path segment) to the aistore bucket backing it. The real implementation -
+// bucket-name validation, BMD lookup, provider defaulting - lives on the
+// target's main request router, outside this package.
+type BckResolver func(name string) (*cmn.Bck, error)
+
+// Handler returns an http.Handler serving every `/v2/<name>/blobs/...` route
+// this package implements, constructing one BlobHandler per request via
+// resolve. Without this, BlobHandler's methods are never invoked by anything:
+// mount it on the target's request router with
+//
+//	mux.Handle(ocidistr.MountPrefix, ocidistr.Handler(t, resolve))
+func Handler(t cluster.Target, resolve BckResolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, rest, ok := splitBlobPath(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		bck, err := resolve(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h := &BlobHandler{T: t, Bck: bck}
+
+		switch {
+		case rest == pathUploads+"/" && r.Method == http.MethodPost:
+			h.StartUpload(w, r, name)
+		case strings.HasPrefix(rest, pathUploads+"/") && r.Method == http.MethodPut:
+			h.Put(w, r, r.URL.Query().Get(URLParamDigest))
+		case r.Method == http.MethodHead:
+			h.Head(w, r, rest)
+		case r.Method == http.MethodGet:
+			h.Get(w, r, rest)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// splitBlobPath parses "<name>/blobs/<rest>" out of a MountPrefix-relative
+// request path and reports whether it looked like a blobs-API path at all.
+func splitBlobPath(path string) (name, rest string, ok bool) {
+	path = strings.TrimPrefix(path, MountPrefix)
+	i := strings.Index(path, "/"+pathBlobs+"/")
+	if i < 0 {
+		return "", "", false
+	}
+	return path[:i], path[i+len("/"+pathBlobs+"/"):], true
+}
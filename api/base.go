@@ -0,0 +1,18 @@
+// Package api is the aistore Go client used by the CLI (and other tools) to
+// talk to a cluster's REST API.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import "net/http"
+
+// BaseParams carries the per-call HTTP client and target endpoint every
+// api.* function needs. Callers construct one per request (or reuse one
+// across calls to the same endpoint) and pass it by value.
+type BaseParams struct {
+	Client *http.Client
+	URL    string
+	Token  string
+	UA     string
+}
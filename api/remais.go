@@ -0,0 +1,64 @@
+// Package api is the aistore Go client used by the CLI (and other tools) to
+// talk to a cluster's REST API.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// apiPathRemoteAISServers is the primary proxy's control endpoint for
+// updating an attached remote AIS cluster's failover URL list. It mirrors
+// the existing attach/detach remote-AIS control paths in shape, not name -
+// those, like the primary-side handler for this one, live outside this
+// checkout.
+const apiPathRemoteAISServers = "/v1/cluster/remote-ais/servers"
+
+// setRemoteAISServersMsg is the wire message the primary's remote-AIS
+// control endpoint expects.
+type setRemoteAISServersMsg struct {
+	Alias string   `json:"alias"`
+	URLs  []string `json:"urls"`
+}
+
+// SetRemoteAISServers pushes a new ordered failover URL list for an attached
+// remote AIS cluster to bp's primary proxy. The primary is expected to
+// validate the list and fan it out to every proxy/target via the usual
+// metasync path, the same distribution mechanism used for every other
+// cluster-wide config change; that server-side handling is not part of this
+// client.
+func SetRemoteAISServers(bp BaseParams, alias string, urls []string) error {
+	body, err := json.Marshal(setRemoteAISServersMsg{Alias: alias, URLs: urls})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, bp.URL+apiPathRemoteAISServers, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if bp.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+bp.Token)
+	}
+	if bp.UA != "" {
+		req.Header.Set("User-Agent", bp.UA)
+	}
+	client := bp.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("set-remote-ais-servers %q: %s", alias, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetRemoteAISServers(t *testing.T) {
+	var got setRemoteAISServersMsg
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if r.URL.Path != apiPathRemoteAISServers {
+			t.Errorf("path = %s, want %s", r.URL.Path, apiPathRemoteAISServers)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	bp := BaseParams{Client: srv.Client(), URL: srv.URL}
+	urls := []string{"http://ais1:51080", "http://ais2:51080"}
+	if err := SetRemoteAISServers(bp, "backup", urls); err != nil {
+		t.Fatalf("SetRemoteAISServers: %v", err)
+	}
+	if got.Alias != "backup" {
+		t.Errorf("alias = %q, want %q", got.Alias, "backup")
+	}
+	if len(got.URLs) != len(urls) || got.URLs[0] != urls[0] || got.URLs[1] != urls[1] {
+		t.Errorf("urls = %v, want %v", got.URLs, urls)
+	}
+}
+
+func TestSetRemoteAISServersError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "alias not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	bp := BaseParams{Client: srv.Client(), URL: srv.URL}
+	if err := SetRemoteAISServers(bp, "nope", []string{"http://ais1:51080"}); err == nil {
+		t.Fatal("expected an error for a 404 response, got nil")
+	}
+}
@@ -63,18 +63,35 @@ type (
 		UnregRecv()
 	}
 	PutObjectParams struct {
-		Reader       io.ReadCloser
-		WorkFQN      string
-		RecvType     RecvType
-		Cksum        *cmn.Cksum // checksum to check
-		Started      time.Time
-		WithFinalize bool // determines if we should also finalize the object
-		SkipEncode   bool // Do not run EC encode after finalizing
+		Reader   io.ReadCloser
+		WorkFQN  string
+		RecvType RecvType
+		Cksum    *cmn.Cksum // checksum to check
+		// ExpectedDigest is the "algo:hex" digest (see fs.Verifier) the caller
+		// has for the object's content. Target.PutObject does not read it: the
+		// Cksum check above is the only verification made on PUT.
+		ExpectedDigest string
+		Started        time.Time
+		WithFinalize   bool // determines if we should also finalize the object
+		SkipEncode     bool // Do not run EC encode after finalizing
 	}
 	CopyObjectParams struct {
 		BckTo *Bck
 		Buf   []byte
 		DM    DataMover
+		// VerifyDigest is set by XactBckCopy, whose jogger skips the copy of
+		// digest-aware content (see fs.DigestAwareResolver) when the destination
+		// already holds the source's digest. Target.CopyObject does not read it:
+		// the bytes it copies are not re-verified.
+		VerifyDigest bool
+		// PreserveXattrs is set by XactBckCopy, whose jogger copies the source
+		// FQN's extended attributes (see fs.ListXattrs/fs.ApplyXattrs) onto the
+		// destination after a successful copy. Target.CopyObject does not read it.
+		PreserveXattrs bool
+		// PreserveSparse is passed to Target.CopyObject, which does not read it:
+		// holes are copied as zeroes by the buffered copy. fs.CopySparse, the
+		// hole-preserving copy, is not called from the copy path.
+		PreserveSparse bool
 	}
 	SendToParams struct {
 		Reader    cmn.ReadOpenCloser
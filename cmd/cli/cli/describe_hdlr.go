@@ -0,0 +1,269 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file contains implementation of the top-level `describe` command.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/urfave/cli"
+)
+
+// `ais describe` is the kubectl-describe-style counterpart to the narrower
+// `ais show ...` subcommands: one consolidated, multi-section report for a
+// single addressable resource (node, bucket, job, or mountpath), built out
+// of the same fillNodeStatusMap/flattenConfig/diffConfigs/showBucketProps/
+// xactList-style helpers the `show` handlers already use, rather than
+// duplicating them.
+
+const (
+	commandDescribe = "describe"
+	cmdDscNode      = "node"
+	cmdDscJob       = "job"
+	cmdDscMpath     = "mountpath"
+)
+
+var (
+	describeSectionsFlag = cli.StringFlag{
+		Name:  "sections",
+		Usage: "comma-separated list of sections to show, e.g. '--sections=identity,config' (default: all)",
+	}
+
+	describeCmdsFlags = map[string][]cli.Flag{
+		cmdDscNode: {
+			describeSectionsFlag,
+			jsonFlag,
+		},
+		cmdBucket: {
+			describeSectionsFlag,
+			jsonFlag,
+			compactPropFlag,
+		},
+		cmdDscJob: {
+			describeSectionsFlag,
+			jsonFlag,
+		},
+		cmdDscMpath: {
+			describeSectionsFlag,
+			noHeaderFlag,
+		},
+	}
+
+	describeCmd = cli.Command{
+		Name:         commandDescribe,
+		Usage:        "show a consolidated, multi-section report for a single node, bucket, job, or mountpath",
+		BashComplete: describeCompletions,
+		Subcommands: []cli.Command{
+			{
+				Name:         cmdDscNode,
+				Usage:        "describe a target or proxy: identity, Smap membership, config diffs, recent xactions, log tail",
+				ArgsUsage:    nodeIDArgument,
+				Flags:        describeCmdsFlags[cmdDscNode],
+				Action:       describeNodeHandler,
+				BashComplete: suggestAllNodes,
+			},
+			{
+				Name:         cmdBucket,
+				Usage:        "describe a bucket: properties and recent xactions touching it",
+				ArgsUsage:    bucketAndPropsArgument,
+				Flags:        describeCmdsFlags[cmdBucket],
+				Action:       describeBucketHandler,
+				BashComplete: bucketAndPropsCompletions,
+			},
+			{
+				Name:         cmdDscJob,
+				Usage:        "describe a job (xaction): per-target snap detail for a single JOB_ID",
+				ArgsUsage:    jobIDArgument,
+				Flags:        describeCmdsFlags[cmdDscJob],
+				Action:       describeJobHandler,
+				BashComplete: runningJobCompletions,
+			},
+			{
+				Name:         cmdDscMpath,
+				Usage:        "describe a target's mountpaths: available, disabled, and detaching",
+				ArgsUsage:    nodeIDArgument,
+				Flags:        describeCmdsFlags[cmdDscMpath],
+				Action:       describeMpathHandler,
+				BashComplete: suggestAllNodes,
+			},
+		},
+	}
+)
+
+// describeCompletions merges node-ID, bucket-URI, and running-job-ID
+// suggestions for `ais describe <TAB>`, since at that point the CLI doesn't
+// yet know which of the four resource kinds the user is about to name.
+func describeCompletions(c *cli.Context) {
+	suggestAllNodes(c)
+	bucketAndPropsCompletions(c)
+	runningJobCompletions(c)
+}
+
+// wantSection reports whether `name` should be printed, honoring --sections.
+func wantSection(c *cli.Context, name string) bool {
+	only := parseStrFlag(c, describeSectionsFlag)
+	if only == "" {
+		return true
+	}
+	for _, s := range strings.Split(only, ",") {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeSection(c *cli.Context, name, caption string, printFn func() error) error {
+	if !wantSection(c, name) {
+		return nil
+	}
+	if caption != "" {
+		fmt.Fprintln(c.App.Writer)
+		actionCptn(c, caption, "")
+	}
+	return printFn()
+}
+
+func describeNodeHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	node, sname, err := getNode(c, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	smap, tstatusMap, pstatusMap, err := fillNodeStatusMap(c, node.Type())
+	if err != nil {
+		return err
+	}
+	cluConfig, err := api.GetClusterConfig(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	nodeConfig, err := api.GetDaemonConfig(apiBP, node)
+	if err != nil {
+		return V(err)
+	}
+
+	if err := describeSection(c, "identity", sname+": identity, Smap membership, version/build", func() error {
+		return cluDaeStatus(c, smap, tstatusMap, pstatusMap, cluConfig, node.ID())
+	}); err != nil {
+		return err
+	}
+
+	if err := describeSection(c, "config", sname+": inherited config diffs", func() error {
+		flatNode := flattenConfig(nodeConfig.ClusterConfig, "")
+		flatCluster := flattenConfig(cluConfig, "")
+		diff := diffConfigs(flatNode, flatCluster)
+		if len(diff) == 0 {
+			fmt.Fprintln(c.App.Writer, "(no differences from cluster config)")
+			return nil
+		}
+		return teb.Print(diff, teb.ConfigTmpl, teb.Jopts(flagIsSet(c, jsonFlag)))
+	}); err != nil {
+		return err
+	}
+
+	if err := describeSection(c, "xactions", sname+": recent xactions", func() error {
+		xargs := xact.ArgsMsg{DaemonID: node.ID(), OnlyRunning: false}
+		_, err := xactList(c, xargs, false /*caption*/)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return describeSection(c, "log", sname+": recent log lines", func() error {
+		return showNodeLogHandler(c)
+	})
+}
+
+func describeBucketHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	fullObjName := c.Args().Get(0)
+	bck, _, err := parseBckObjURI(c, fullObjName, true /*optionalObjName*/)
+	if err != nil {
+		return err
+	}
+	if _, err := headBucket(bck, true /* don't add */); err != nil {
+		return err
+	}
+
+	if err := describeSection(c, "properties", bck.DisplayName()+": properties", func() error {
+		return showBucketProps(c)
+	}); err != nil {
+		return err
+	}
+
+	return describeSection(c, "xactions", bck.DisplayName()+": recent xactions", func() error {
+		xargs := xact.ArgsMsg{Bck: bck, OnlyRunning: false}
+		_, err := xactList(c, xargs, false /*caption*/)
+		return err
+	})
+}
+
+func describeJobHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	xid := c.Args().Get(0)
+	name, _ := xid2Name(xid)
+
+	return describeSection(c, "xaction", jobName(name, xid), func() error {
+		xargs := xact.ArgsMsg{ID: xid, OnlyRunning: false}
+		l, err := xactList(c, xargs, false /*caption*/)
+		if err == nil && l == 0 {
+			fmt.Fprintf(c.App.Writer, "Job %q not found (neither running nor finished)\n", xid)
+		}
+		return err
+	})
+}
+
+func describeMpathHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	node, sname, err := getNode(c, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	mpl, err := api.GetMountpaths(apiBP, node)
+	if err != nil {
+		return V(err)
+	}
+
+	return describeSection(c, "mountpaths", sname+": mountpaths", func() error {
+		return printMpathList(c, mpl)
+	})
+}
+
+// printMpathList renders the three mountpath buckets api.GetMountpaths
+// returns: currently serving I/O, administratively disabled, and in the
+// process of being detached (see apc.MountpathList).
+func printMpathList(c *cli.Context, mpl *apc.MountpathList) error {
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "MOUNTPATH\tSTATUS")
+	}
+	for _, mpath := range mpl.Available {
+		fmt.Fprintf(tw, "%s\tavailable\n", mpath)
+	}
+	for _, mpath := range mpl.WaitingDD {
+		fmt.Fprintf(tw, "%s\tdetaching (draining)\n", mpath)
+	}
+	for _, mpath := range mpl.Disabled {
+		fmt.Fprintf(tw, "%s\tdisabled\n", mpath)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,153 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file contains commands for dynamic remote-AIS endpoint management, analogous to
+// Nomad's agent SetServers/Servers API.
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
+	"github.com/urfave/cli"
+)
+
+const cmdRemAisServers = "servers"
+
+var (
+	forceRemAisServersFlag = cli.BoolFlag{
+		Name:  "force",
+		Usage: "skip the health-check preflight and push the server list unconditionally",
+	}
+
+	showCmdRemoteAISServers = cli.Command{
+		Name:      cmdRemAisServers,
+		Usage:     "show the ordered failover URL list for an attached remote AIS cluster, with per-URL health/uptime",
+		ArgsUsage: "ALIAS",
+		Flags: []cli.Flag{
+			noHeaderFlag,
+		},
+		Action: showRemoteAISServersHandler,
+	}
+
+	setRemoteAISServersCmd = cli.Command{
+		Name:      "set-remote-ais-servers",
+		Usage:     "update the ordered list of URLs used to reach an attached remote AIS cluster",
+		ArgsUsage: "ALIAS URL[,URL...]",
+		Flags: []cli.Flag{
+			forceRemAisServersFlag,
+		},
+		Action: setRemoteAISServersHandler,
+	}
+
+	// clusterCmd holds the cluster-level, state-changing counterparts to the
+	// read-only `ais show cluster ...` tree: unlike `show`, these commands
+	// mutate cluster or remote-AIS configuration, so they get their own
+	// top-level namespace instead of hanging off showCmdRemoteAIS.
+	clusterCmd = cli.Command{
+		Name:  cmdCluster,
+		Usage: "cluster and remote-AIS management: state-changing operations",
+		Subcommands: []cli.Command{
+			setRemoteAISServersCmd,
+		},
+	}
+)
+
+// showRemoteAISServersHandler implements `ais show remote-ais servers <alias>`.
+func showRemoteAISServersHandler(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "alias of the attached remote AIS cluster")
+	}
+	alias := c.Args().Get(0)
+
+	all, err := api.GetRemoteAIS(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	var found bool
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "URL\tSTATUS\tUPTIME")
+	}
+	for _, ra := range all.A {
+		if ra.Alias != alias && ra.UUID != alias {
+			continue
+		}
+		found = true
+		// NOTE: until the multi-URL failover list lands server-side, each
+		// alias only ever has the one currently-known URL.
+		status, uptime := probeRemAisURL(ra.URL)
+		fmt.Fprintf(tw, "* %s\t%s\t%s\n", ra.URL, status, uptime)
+	}
+	if !found {
+		return fmt.Errorf("remote AIS cluster %q is not attached", alias)
+	}
+	return tw.Flush()
+}
+
+// setRemoteAISServersHandler implements `ais cluster set-remote-ais-servers <alias> url1,url2,url3`.
+func setRemoteAISServersHandler(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "ALIAS URL[,URL...]")
+	}
+	alias := c.Args().Get(0)
+	urls := splitNonEmpty(c.Args().Get(1), ",")
+	if len(urls) == 0 {
+		return fmt.Errorf("URL list cannot be empty (mirrors the remote AIS's current primary-URL requirement)")
+	}
+
+	if !flagIsSet(c, forceRemAisServersFlag) && !anyRemAisURLHealthy(urls) {
+		return fmt.Errorf("none of the given URLs responded to a health check; use %s to push anyway",
+			qflprn(forceRemAisServersFlag))
+	}
+
+	// api.SetRemoteAISServers PUTs the new server list to the primary proxy's
+	// remote-AIS control endpoint (see api/remais.go). The primary-side
+	// handler that validates the list and fans it out to every proxy/target
+	// via metasync - analogous to the existing ActAttachRemAis/ActDetachRemAis
+	// control messages - is not part of this checkout, same as every other
+	// cluster-wide action handler (ais/prxclu.go and friends aren't vendored
+	// here); this call is the client half only.
+	if err := api.SetRemoteAISServers(apiBP, alias, urls); err != nil {
+		return V(err)
+	}
+	actionDone(c, fmt.Sprintf("remote AIS cluster %q: server list updated (%d URL(s))", alias, len(urls)))
+	return nil
+}
+
+func splitNonEmpty(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func probeRemAisURL(url string) (status, uptime string) {
+	bp := api.BaseParams{Client: defaultHTTPClient, URL: url, Token: loggedUserToken, UA: ua}
+	clutime, _, err := api.HealthUptime(bp)
+	if err != nil {
+		return "offline", teb.UnknownStatusVal
+	}
+	ns, _ := strconv.ParseInt(clutime, 10, 64)
+	return "online", time.Duration(ns).String()
+}
+
+func anyRemAisURLHealthy(urls []string) bool {
+	for _, url := range urls {
+		bp := api.BaseParams{Client: defaultHTTPClient, URL: url, Token: loggedUserToken, UA: ua}
+		if _, _, err := api.HealthUptime(bp); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -189,6 +189,9 @@ var (
 		ArgsUsage: "",
 		Flags:     showCmdsFlags[cmdShowRemoteAIS],
 		Action:    showRemoteAISHandler,
+		Subcommands: []cli.Command{
+			showCmdRemoteAISServers,
+		},
 	}
 
 	showCmdLog = cli.Command{
@@ -37,6 +37,7 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	OCIBlobType  = "oc"
 )
 
 type (
@@ -57,6 +58,17 @@ type (
 		ParseUniqueFQN(base string) (orig string, old, ok bool)
 	}
 
+	// DigestAwareResolver is implemented by content types that want bytes
+	// verified against a digest (e.g. "sha256:<hex>") as they stream through
+	// the write path, instead of (or in addition to) a plain cmn.Cksum check.
+	// Implement it alongside ContentResolver; contentSpecMgr type-asserts for it.
+	DigestAwareResolver interface {
+		ContentResolver
+		// DigestAlgorithm names the algorithm (as registered via contentSpecMgr.RegDigest)
+		// used to verify and/or name objects of this content type.
+		DigestAlgorithm() string
+	}
+
 	PartsFQN interface {
 		ObjectName() string
 		Bucket() *cmn.Bck
@@ -72,7 +84,8 @@ type (
 	}
 
 	contentSpecMgr struct {
-		m map[string]ContentResolver
+		m       map[string]ContentResolver
+		digests map[string]DigestAlgorithm
 	}
 )
 
@@ -99,6 +112,38 @@ func (f *contentSpecMgr) Reg(contentType string, spec ContentResolver) error {
 	return nil
 }
 
+// RegDigest registers a digest algorithm (e.g. "sha256") by name so that
+// DigestAwareResolver implementations can reference it by DigestAlgorithm().
+// Like Reg, all registrations must happen at startup, before any mountpath is used.
+func (f *contentSpecMgr) RegDigest(name string, algo DigestAlgorithm) error {
+	if _, ok := f.digests[name]; ok {
+		return fmt.Errorf("digest algorithm %q is already registered", name)
+	}
+	f.digests[name] = algo
+	return nil
+}
+
+// DigestAlgo returns the algorithm registered for `name`, if any.
+func (f *contentSpecMgr) DigestAlgo(name string) (algo DigestAlgorithm, ok bool) {
+	algo, ok = f.digests[name]
+	return
+}
+
+// NewVerifier returns a streaming Verifier for the content type, if that type
+// implements DigestAwareResolver and its algorithm is registered; otherwise
+// it returns (nil, false) and callers should fall back to cmn.Cksum checks.
+func (f *contentSpecMgr) NewVerifier(contentType string) (v Verifier, ok bool) {
+	dar, isDigestAware := f.m[contentType].(DigestAwareResolver)
+	if !isDigestAware {
+		return nil, false
+	}
+	algo, ok := f.digests[dar.DigestAlgorithm()]
+	if !ok {
+		return nil, false
+	}
+	return algo.NewVerifier(), true
+}
+
 // Gen returns a new FQN generated from given parts.
 func (f *contentSpecMgr) Gen(parts PartsFQN, contentType, prefix string) (fqn string) {
 	var (
@@ -121,7 +166,11 @@ func (f *contentSpecMgr) FileSpec(fqn string) (resolver ContentResolver, info *C
 	}
 	spec, found := f.m[parsedFQN.ContentType]
 	if !found {
-		glog.Errorf("%q: unknown content type %s", fqn, parsedFQN.ContentType)
+		if s, ok := glog.GetStream(contentStreamName); ok {
+			s.Errorf("%q: unknown content type %s", fqn, parsedFQN.ContentType)
+		} else {
+			glog.Errorf("%q: unknown content type %s", fqn, parsedFQN.ContentType)
+		}
 		return
 	}
 	origBase, old, ok := spec.ParseUniqueFQN(base)
@@ -235,3 +284,30 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// OCIBlobContentResolver stores OCI/Docker image-layer blobs keyed by content
+// digest (e.g. "sha256:<hex>") rather than by a user-supplied object name, so
+// that the same blob pushed under different tags resolves to one FQN.
+// Blobs are content-addressed and therefore immutable: once written they are
+// never evicted by LRU, only removed explicitly (e.g. registry GC).
+type OCIBlobContentResolver struct{}
+
+func (*OCIBlobContentResolver) PermToMove() bool        { return true }
+func (*OCIBlobContentResolver) PermToEvict() bool       { return false }
+func (*OCIBlobContentResolver) PermToProcess() bool     { return true }
+func (*OCIBlobContentResolver) DigestAlgorithm() string { return "sha256" }
+
+// GenUniqueFQN expects `base` to already be a digest string ("algo:hex");
+// the colon is replaced with a filesystem-safe separator since PartsFQN
+// FQNs cannot embed the path separator but may contain other punctuation.
+func (*OCIBlobContentResolver) GenUniqueFQN(base, _ string) string {
+	return strings.Replace(base, ":", ".", 1)
+}
+
+func (*OCIBlobContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	i := strings.Index(base, ".")
+	if i < 0 {
+		return "", false, false
+	}
+	return base[:i] + ":" + base[i+1:], false, true
+}
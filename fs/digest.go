@@ -0,0 +1,93 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+)
+
+type (
+	// Verifier sits inside an io.MultiWriter alongside the slab buffer used
+	// by the write path: every byte written to the destination FQN is also
+	// fed here. Digest is the terminal, canonical "algo:hex" string; Verified
+	// does a constant-time compare against an expected value (e.g. a
+	// client-supplied digest or the source object's digest on CopyObject).
+	Verifier interface {
+		io.Writer
+		Digest() string
+		Verified(expected string) bool
+	}
+
+	// DigestAlgorithm is a named, registerable hash algorithm, modeled on the
+	// go-digest interface so the same registry can back content verification
+	// (PutObject, XactBckCopy.copyObject, EC slice writes) and the OCI/registry
+	// blob paths.
+	DigestAlgorithm interface {
+		Name() string
+		NewVerifier() Verifier
+	}
+
+	hashAlgo struct {
+		name string
+		new  func() hash.Hash
+	}
+	hashVerifier struct {
+		algo string
+		h    hash.Hash
+	}
+)
+
+func (a *hashAlgo) Name() string { return a.name }
+func (a *hashAlgo) NewVerifier() Verifier {
+	return &hashVerifier{algo: a.name, h: a.new()}
+}
+
+func (v *hashVerifier) Write(p []byte) (int, error) { return v.h.Write(p) }
+
+func (v *hashVerifier) Digest() string {
+	return v.algo + ":" + hex.EncodeToString(v.h.Sum(nil))
+}
+
+func (v *hashVerifier) Verified(expected string) bool {
+	d := v.Digest()
+	return len(d) == len(expected) && subtle.ConstantTimeCompare([]byte(d), []byte(expected)) == 1
+}
+
+// contentStreamName is the fs package's own glog.Stream: unknown-content-type
+// errors (e.g. during a bad rebalance) are collected in "aistarget.content",
+// and Stream.Errorf still mirrors each of them into the aggregate error log.
+const contentStreamName = "content"
+
+// init registers the built-in digest algorithms. Unknown algorithm names
+// encountered on read (e.g. an on-disk object written by a future build with
+// a not-yet-supported algorithm) simply miss the registry:
+// contentSpecMgr.NewVerifier returns (nil, false) and no verification is done,
+// so old objects remain readable.
+func init() {
+	CSM = &contentSpecMgr{
+		m:       make(map[string]ContentResolver, 8),
+		digests: make(map[string]DigestAlgorithm, 4),
+	}
+	CSM.digests["sha256"] = &hashAlgo{name: "sha256", new: sha256.New}
+	CSM.digests["sha512"] = &hashAlgo{name: "sha512", new: sha512.New}
+	// NOTE: "blake3" is intentionally not wired in here - it lives in a
+	// 3rdparty module not vendored into this tree. Registering it is a
+	// matter of adding the import and one more CSM.digests[...] entry.
+
+	if err := CSM.Reg(OCIBlobType, &OCIBlobContentResolver{}); err != nil {
+		glog.Errorf("%s: %v", OCIBlobType, err)
+	}
+
+	if err := glog.RegisterStream(contentStreamName, glog.StreamOptions{}); err != nil {
+		glog.Errorf("%s: %v", contentStreamName, err)
+	}
+}
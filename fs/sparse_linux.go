@@ -0,0 +1,69 @@
+//go:build linux
+
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package fs
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// lseek(2) whence values not exposed by the stdlib syscall package.
+const (
+	seekData = 3 // SEEK_DATA
+	seekHole = 4 // SEEK_HOLE
+)
+
+// CopySparse copies `size` bytes from src to dst preserving holes: instead of
+// writing zeroes for unallocated regions, it ftruncates dst to the full size
+// up front and only writes the byte ranges SEEK_DATA/SEEK_HOLE report as
+// actual data, leaving the rest of the file sparse on filesystems that support it.
+//
+// ok is false (with err possibly nil) when the source filesystem doesn't
+// support SEEK_HOLE/SEEK_DATA; callers should fall back to a plain buffered copy.
+func CopySparse(src, dst *os.File, size int64, buf []byte) (ok bool, err error) {
+	if err := syscall.Ftruncate(int(dst.Fd()), size); err != nil {
+		return false, err
+	}
+	if size == 0 {
+		return true, nil
+	}
+
+	srcFd := int(src.Fd())
+	offset := int64(0)
+	for offset < size {
+		dataStart, serr := syscall.Seek(srcFd, offset, seekData)
+		if serr != nil {
+			if serr == syscall.ENXIO {
+				break // no more data - remainder to EOF is a hole
+			}
+			return false, nil // SEEK_DATA unsupported on this fs - fall back
+		}
+		holeStart, serr := syscall.Seek(srcFd, dataStart, seekHole)
+		if serr != nil {
+			return false, serr
+		}
+		if err := copySparseRange(src, dst, dataStart, holeStart, buf); err != nil {
+			return false, err
+		}
+		offset = holeStart
+	}
+	return true, nil
+}
+
+func copySparseRange(src, dst *os.File, from, to int64, buf []byte) error {
+	if _, err := src.Seek(from, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := dst.Seek(from, io.SeekStart); err != nil {
+		return err
+	}
+	r := io.LimitReader(src, to-from)
+	_, err := io.CopyBuffer(dst, r, buf)
+	return err
+}
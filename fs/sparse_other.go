@@ -0,0 +1,16 @@
+//go:build !linux
+
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package fs
+
+import "os"
+
+// CopySparse always reports unsupported on non-Linux platforms; callers fall
+// back to a plain buffered copy.
+func CopySparse(src, dst *os.File, size int64, buf []byte) (ok bool, err error) {
+	return false, nil
+}
@@ -0,0 +1,108 @@
+//go:build linux
+
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package fs
+
+import (
+	"strings"
+	"syscall"
+)
+
+// DefaultXattrPrefixes are the xattr namespaces that XactBckCopy passes to
+// ListXattrs when it preserves extended attributes. "security.*" is deliberately
+// excluded by default (e.g. SELinux labels, capabilities) - callers that want
+// it must opt in explicitly.
+var DefaultXattrPrefixes = []string{"user.ais.", "user."}
+
+// xattrListBufSize is the starting guess for Listxattr/Getxattr buffers;
+// growXattrBuf doubles it (up to xattrListBufMax) on ERANGE, so this only
+// affects how many syscalls a large attribute list costs, not correctness.
+const (
+	xattrListBufSize = 4 * 1024
+	xattrListBufMax  = 4 * 1024 * 1024
+)
+
+// ListXattrs returns the names (and payloads) of all extended attributes set
+// on `fqn` whose name starts with one of `prefixes`. An empty `prefixes`
+// matches every xattr.
+func ListXattrs(fqn string, prefixes []string) (map[string][]byte, error) {
+	buf, n, err := growXattrBuf(func(b []byte) (int, error) { return syscall.Listxattr(fqn, b) })
+	if err != nil {
+		return nil, err
+	}
+	attrs := make(map[string][]byte)
+	for _, name := range splitXattrNames(buf[:n]) {
+		if !matchesXattrPrefix(name, prefixes) {
+			continue
+		}
+		val, err := getXattr(fqn, name)
+		if err != nil {
+			return nil, err
+		}
+		attrs[name] = val
+	}
+	return attrs, nil
+}
+
+// ApplyXattrs sets every (name, value) pair from `attrs` on `fqn`, overwriting
+// any existing value. Intended to run right after a data copy completes so
+// the destination FQN ends up with the same metadata as the source.
+func ApplyXattrs(fqn string, attrs map[string][]byte) error {
+	for name, val := range attrs {
+		if err := syscall.Setxattr(fqn, name, val, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchesXattrPrefix(name string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitXattrNames(buf []byte) []string {
+	var names []string
+	for _, raw := range strings.Split(string(buf), "\x00") {
+		if raw != "" {
+			names = append(names, raw)
+		}
+	}
+	return names
+}
+
+func getXattr(fqn, name string) ([]byte, error) {
+	buf, n, err := growXattrBuf(func(b []byte) (int, error) { return syscall.Getxattr(fqn, name, b) })
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, n)
+	copy(out, buf[:n])
+	return out, nil
+}
+
+// growXattrBuf calls `do` with successively larger buffers, starting at
+// xattrListBufSize and doubling on syscall.ERANGE (the buffer was too
+// small), up to xattrListBufMax. Any other error, or still-too-small at the
+// max size, is returned as-is.
+func growXattrBuf(do func([]byte) (int, error)) (buf []byte, n int, err error) {
+	for size := xattrListBufSize; size <= xattrListBufMax; size *= 2 {
+		buf = make([]byte, size)
+		n, err = do(buf)
+		if err != syscall.ERANGE {
+			return buf, n, err
+		}
+	}
+	return nil, 0, err
+}
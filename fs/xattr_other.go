@@ -0,0 +1,17 @@
+//go:build !linux
+
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package fs
+
+import "errors"
+
+var DefaultXattrPrefixes = []string{"user.ais.", "user."}
+
+var errXattrUnsupported = errors.New("extended attributes are not supported on this platform")
+
+func ListXattrs(string, []string) (map[string][]byte, error) { return nil, errXattrUnsupported }
+func ApplyXattrs(string, map[string][]byte) error            { return errXattrUnsupported }
@@ -0,0 +1,56 @@
+//go:build linux
+
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// setCPUAffinity pins the calling OS thread to the CPUs named by mask, a
+// comma-separated list of CPU numbers and/or ranges (e.g. "0-3,6"). It must
+// be called after runtime.LockOSThread() so the pinning sticks.
+func setCPUAffinity(mask string) error {
+	var cpuset uint64
+	for _, part := range strings.Split(mask, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, err := parseCPURange(part)
+		if err != nil {
+			return err
+		}
+		for cpu := lo; cpu <= hi; cpu++ {
+			if cpu >= 64 {
+				return fmt.Errorf("cpu affinity: cpu %d exceeds supported mask width", cpu)
+			}
+			cpuset |= 1 << uint(cpu)
+		}
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, 0, unsafe.Sizeof(cpuset), uintptr(unsafe.Pointer(&cpuset)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func parseCPURange(part string) (lo, hi int, err error) {
+	if i := strings.IndexByte(part, '-'); i >= 0 {
+		lo, err = strconv.Atoi(part[:i])
+		if err != nil {
+			return 0, 0, err
+		}
+		hi, err = strconv.Atoi(part[i+1:])
+		return lo, hi, err
+	}
+	lo, err = strconv.Atoi(part)
+	return lo, lo, err
+}
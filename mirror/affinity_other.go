@@ -0,0 +1,13 @@
+//go:build !linux
+
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import "errors"
+
+func setCPUAffinity(string) error {
+	return errors.New("CPU affinity pinning is only supported on Linux")
+}
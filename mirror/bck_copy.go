@@ -6,6 +6,9 @@ package mirror
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"runtime"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -18,6 +21,20 @@ import (
 
 // XactBckCopy copies a bucket locally within the same cluster
 
+// copyStream is this xaction's own rotated log stream (aistarget.copy),
+// registered once at package init so a busy ActCopyBucket job doesn't drown
+// the primary INFO log; see 3rdparty/glog.RegisterStream.
+var copyStream *glog.Stream
+
+func init() {
+	const name = "copy"
+	if err := glog.RegisterStream(name, glog.StreamOptions{}); err != nil {
+		glog.Errorf("%s: %v", name, err)
+		return
+	}
+	copyStream, _ = glog.GetStream(name)
+}
+
 type (
 	XactBckCopy struct {
 		xactBckBase
@@ -25,6 +42,14 @@ type (
 		bckFrom *cluster.Bck
 		bckTo   *cluster.Bck
 		dm      *transport.DataMover
+		// preserveXattrs/preserveSparse/verifyDigest control fidelity of the
+		// per-object copy (see cluster.CopyObjectParams); all default to
+		// false so existing ActCopyBucket jobs keep doing the plain
+		// buffered copy.
+		preserveXattrs bool
+		preserveSparse bool
+		verifyDigest   bool
+		lim            *xactLimiter // nil when no XactLimits were given
 	}
 	bccJogger struct { // one per mountpath
 		joggerBckBase
@@ -38,22 +63,33 @@ type (
 //
 
 func NewXactBCC(id string, bckFrom, bckTo *cluster.Bck, t cluster.Target, slab *memsys.Slab,
-	dm *transport.DataMover) *XactBckCopy {
-	return &XactBckCopy{
-		xactBckBase: *newXactBckBase(id, cmn.ActCopyBucket, bckTo.Bck, t),
-		slab:        slab,
-		bckFrom:     bckFrom,
-		bckTo:       bckTo,
-		dm:          dm,
+	dm *transport.DataMover, preserveXattrs, preserveSparse, verifyDigest bool, limits *XactLimits) *XactBckCopy {
+	r := &XactBckCopy{
+		xactBckBase:    *newXactBckBase(id, cmn.ActCopyBucket, bckTo.Bck, t),
+		slab:           slab,
+		bckFrom:        bckFrom,
+		bckTo:          bckTo,
+		dm:             dm,
+		preserveXattrs: preserveXattrs,
+		preserveSparse: preserveSparse,
+		verifyDigest:   verifyDigest,
+	}
+	if limits != nil {
+		r.lim = newXactLimiter(*limits)
 	}
+	return r
 }
 
+// Stats returns the running resource-limit counters (queued, in-flight,
+// completed, throttled-ms) for display alongside the xaction's regular stats.
+func (r *XactBckCopy) Stats() XactLimitStats { return r.lim.stats() }
+
 func (r *XactBckCopy) Run() (err error) {
 	r.dm.Open()
 
 	mpathCount := r.runJoggers()
 
-	glog.Infoln(r.String(), r.bckFrom.Bck, "=>", r.bckTo.Bck)
+	copyStream.Infof("%s %s => %s", r.String(), r.bckFrom.Bck, r.bckTo.Bck)
 	err = r.xactBckBase.waitDone(mpathCount)
 
 	time.Sleep(2 * time.Second) // TODO -- FIXME: quiesce
@@ -107,18 +143,60 @@ func newBCCJogger(parent *XactBckCopy, mpathInfo *fs.MountpathInfo, config *cmn.
 }
 
 func (j *bccJogger) jog() {
-	glog.Infof("jogger[%s/%s] started", j.mpathInfo, j.parent.bckFrom.Bck)
+	copyStream.Infof("jogger[%s/%s] started", j.mpathInfo, j.parent.bckFrom.Bck)
+	if j.parent.lim != nil && (j.parent.lim.limits.CgroupPath != "" || j.parent.lim.limits.CPUAffinity != "") {
+		// Gettid()/SCHED_SETAFFINITY only apply to the calling OS thread.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		j.parent.lim.joinCgroup()
+		if j.parent.lim.limits.CPUAffinity != "" {
+			if err := setCPUAffinity(j.parent.lim.limits.CPUAffinity); err != nil {
+				copyStream.Errorf("cpu-affinity %q: %v", j.parent.lim.limits.CPUAffinity, err)
+			}
+		}
+	}
 	j.buf = j.parent.slab.Alloc()
 	j.joggerBckBase.jog()
 	j.parent.slab.Free(j.buf)
 }
 
 func (j *bccJogger) copyObject(lom *cluster.LOM) error {
+	release := j.parent.lim.acquire(lom.Size())
+	defer release()
+
+	if j.parent.verifyDigest {
+		if skip, err := j.skipOnMatchingDigest(lom); err != nil {
+			copyStream.Errorf("%s: digest verify: %v", lom.ObjName, err)
+		} else if skip {
+			j.parent.ObjectsInc()
+			j.parent.BytesAdd(lom.Size())
+			j.num++
+			return nil
+		}
+	}
+
 	var (
-		params      = cluster.CopyObjectParams{BckTo: j.parent.bckTo, Buf: j.buf, DM: j.parent.dm}
+		params = cluster.CopyObjectParams{
+			BckTo: j.parent.bckTo,
+			Buf:   j.buf,
+			DM:    j.parent.dm,
+			// PreserveSparse is passed through for Target.CopyObject to honor
+			// during its own data-transfer phase (fs.CopySparse reads/writes
+			// both FQNs while they're still open, so there's nothing left to
+			// reapply here afterwards, unlike xattrs below). NOTE: as of this
+			// commit fs.CopySparse has no caller anywhere in this tree, so
+			// setting this flag is a no-op until Target.CopyObject's real
+			// implementation calls it.
+			PreserveSparse: j.parent.preserveSparse,
+			PreserveXattrs: j.parent.preserveXattrs,
+			VerifyDigest:   j.parent.verifyDigest,
+		}
 		copied, err = j.parent.Target().CopyObject(lom, params)
 	)
 	if copied {
+		if j.parent.preserveXattrs {
+			j.copyXattrs(lom)
+		}
 		j.parent.ObjectsInc()
 		j.parent.BytesAdd(lom.Size() + lom.Size()) // FIXME: depends on whether local <-> remote
 		j.num++
@@ -135,3 +213,81 @@ func (j *bccJogger) copyObject(lom *cluster.LOM) error {
 	}
 	return err
 }
+
+// copyXattrs best-effort-reapplies the source FQN's extended attributes onto
+// the just-copied destination object. It runs here, in the jogger, rather
+// than inside Target.CopyObject's data-transfer path, because xattrs are
+// independent metadata that can be read/written after the fact; a failure
+// here only logs (via copyStream.Errorf) and does not fail the copy, since
+// the object data itself already landed successfully.
+func (j *bccJogger) copyXattrs(lom *cluster.LOM) {
+	dst := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(dst)
+	if err := dst.Init(j.parent.bckTo.Bck); err != nil {
+		copyStream.Errorf("%s: xattrs: %v", lom.ObjName, err)
+		return
+	}
+	if err := dst.Load(false /*cacheIt*/, false /*locked*/); err != nil {
+		copyStream.Errorf("%s: xattrs: %v", lom.ObjName, err)
+		return
+	}
+	attrs, err := fs.ListXattrs(lom.FQN, fs.DefaultXattrPrefixes)
+	if err != nil {
+		copyStream.Errorf("%s: list xattrs: %v", lom.ObjName, err)
+		return
+	}
+	if len(attrs) == 0 {
+		return
+	}
+	if err := fs.ApplyXattrs(dst.FQN, attrs); err != nil {
+		copyStream.Errorf("%s: apply xattrs: %v", lom.ObjName, err)
+	}
+}
+
+// skipOnMatchingDigest reports whether the destination already holds bytes
+// identical to the source, so the data-transfer phase can be skipped
+// entirely. It only applies to digest-aware content (see
+// fs.DigestAwareResolver, e.g. fs.OCIBlobType): plain "ob" objects have no
+// registered fs.Verifier, so (false, nil) is returned immediately for them -
+// this is a pure optimization, never a copy-correctness check.
+func (j *bccJogger) skipOnMatchingDigest(lom *cluster.LOM) (bool, error) {
+	_, info := fs.CSM.FileSpec(lom.FQN)
+	if info == nil {
+		return false, nil
+	}
+	verifier, ok := fs.CSM.NewVerifier(info.Type)
+	if !ok {
+		return false, nil
+	}
+	dst := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(dst)
+	if err := dst.Init(j.parent.bckTo.Bck); err != nil {
+		return false, nil
+	}
+	if err := dst.Load(false /*cacheIt*/, false /*locked*/); err != nil {
+		return false, nil // destination doesn't exist yet - nothing to skip
+	}
+	srcDigest, err := fileDigest(lom.FQN, verifier)
+	if err != nil {
+		return false, err
+	}
+	dstVerifier, _ := fs.CSM.NewVerifier(info.Type)
+	dstDigest, err := fileDigest(dst.FQN, dstVerifier)
+	if err != nil {
+		return false, err
+	}
+	return srcDigest == dstDigest, nil
+}
+
+// fileDigest streams fqn's bytes through verifier and returns the resulting digest.
+func fileDigest(fqn string, verifier fs.Verifier) (string, error) {
+	f, err := os.Open(fqn)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(verifier, f); err != nil {
+		return "", err
+	}
+	return verifier.Digest(), nil
+}
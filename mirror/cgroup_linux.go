@@ -0,0 +1,38 @@
+//go:build linux
+
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// writeTidToCgroup appends the calling OS thread's tid to
+// <cgroupPath>/cgroup.threads, joining whatever io/cpu/memory controllers the
+// operator configured on that cgroup v2 node.
+//
+// This must target cgroup.threads, not cgroup.procs: writing a tid to
+// cgroup.procs migrates the tid's entire thread-group (i.e. the whole aistarget
+// process, not just the calling jogger's locked OS thread) into cgroupPath,
+// which would move every other xaction and the main process along with it.
+// cgroup.threads only exists, and only accepts writes, once cgroupPath has
+// been put into "threaded" mode (echo threaded > cgroupPath/cgroup.type) -
+// that's an operator/deployment-time precondition, not something this call
+// can establish on its own.
+func writeTidToCgroup(cgroupPath string) error {
+	tid := syscall.Gettid()
+	threads := filepath.Join(cgroupPath, "cgroup.threads")
+	f, err := os.OpenFile(threads, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("%s (is %q in \"threaded\" cgroup.type mode?): %w", threads, cgroupPath, err)
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%d\n", tid)
+	return err
+}
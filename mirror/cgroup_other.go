@@ -0,0 +1,13 @@
+//go:build !linux
+
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import "errors"
+
+func writeTidToCgroup(string) error {
+	return errors.New("cgroup v2 task assignment is only supported on Linux")
+}
@@ -0,0 +1,170 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+)
+
+// XactLimits caps the resources a single XactBckCopy is allowed to consume so
+// that a large ActCopyBucket job doesn't starve user-facing GETs on a shared
+// production target. All fields are optional - zero value means "unlimited"
+// for that particular dimension.
+type XactLimits struct {
+	MaxJoggers       int     // max number of copyObject calls in flight across all mountpath joggers
+	MaxInflightBytes int64   // leaky-bucket capacity/refill target for bytes/s
+	MaxIOPS          float64 // leaky-bucket capacity/refill target for objects/s
+	CPUAffinity      string  // e.g. "0-3"; see setCPUAffinity
+	CgroupPath       string  // if set, each jogger's tid is written to <path>/cgroup.threads (Linux only; <path> must be in "threaded" cgroup.type mode)
+}
+
+// XactLimitStats are the running counters exposed through the xaction's
+// regular stats so the CLI can display them alongside ObjectsInc/BytesAdd.
+type XactLimitStats struct {
+	Queued      int64
+	InFlight    int64
+	Completed   int64
+	ThrottledMS int64
+}
+
+// xactLimiter enforces XactLimits for one XactBckCopy. A nil *xactLimiter
+// (i.e. no XactLimits were configured) imposes no limits at all.
+type xactLimiter struct {
+	limits XactLimits
+
+	jobberSem chan struct{} // MaxJoggers concurrency tokens; nil => unlimited
+	bytes     *leakyBucket  // nil => unlimited
+	iops      *leakyBucket  // nil => unlimited
+
+	queued, inFlight, completed, throttledMS int64
+}
+
+func newXactLimiter(limits XactLimits) *xactLimiter {
+	lim := &xactLimiter{limits: limits}
+	if limits.MaxJoggers > 0 {
+		lim.jobberSem = make(chan struct{}, limits.MaxJoggers)
+	}
+	if limits.MaxInflightBytes > 0 {
+		lim.bytes = newLeakyBucket(float64(limits.MaxInflightBytes), float64(limits.MaxInflightBytes))
+	}
+	if limits.MaxIOPS > 0 {
+		lim.iops = newLeakyBucket(limits.MaxIOPS, limits.MaxIOPS)
+	}
+	return lim
+}
+
+// acquire blocks until the call is allowed to proceed, honoring jobber
+// concurrency and the bytes/IOPS leaky buckets; it returns a release func
+// that must be called once the guarded copyObject call returns.
+func (lim *xactLimiter) acquire(size int64) (release func()) {
+	if lim == nil {
+		return func() {}
+	}
+	atomic.AddInt64(&lim.queued, 1)
+	var throttled time.Duration
+	if lim.jobberSem != nil {
+		start := time.Now()
+		lim.jobberSem <- struct{}{}
+		throttled += time.Since(start)
+	}
+	if lim.iops != nil {
+		throttled += lim.iops.take(1)
+	}
+	if lim.bytes != nil {
+		throttled += lim.bytes.take(float64(size))
+	}
+	atomic.AddInt64(&lim.throttledMS, throttled.Milliseconds())
+	atomic.AddInt64(&lim.queued, -1)
+	atomic.AddInt64(&lim.inFlight, 1)
+	return func() {
+		atomic.AddInt64(&lim.inFlight, -1)
+		atomic.AddInt64(&lim.completed, 1)
+		if lim.jobberSem != nil {
+			<-lim.jobberSem
+		}
+	}
+}
+
+func (lim *xactLimiter) stats() XactLimitStats {
+	if lim == nil {
+		return XactLimitStats{}
+	}
+	return XactLimitStats{
+		Queued:      atomic.LoadInt64(&lim.queued),
+		InFlight:    atomic.LoadInt64(&lim.inFlight),
+		Completed:   atomic.LoadInt64(&lim.completed),
+		ThrottledMS: atomic.LoadInt64(&lim.throttledMS),
+	}
+}
+
+// leakyBucket is a simple token bucket: tokens refill continuously at
+// refillPerSec up to max, and take() blocks (leaks time) until enough are
+// available to cover the requested amount.
+type leakyBucket struct {
+	mu           sync.Mutex
+	tokens       float64
+	max          float64
+	refillPerSec float64
+	last         time.Time
+}
+
+func newLeakyBucket(max, refillPerSec float64) *leakyBucket {
+	return &leakyBucket{tokens: max, max: max, refillPerSec: refillPerSec, last: time.Now()}
+}
+
+func (b *leakyBucket) take(n float64) (throttled time.Duration) {
+	// A request larger than the bucket's own capacity (e.g. a single object
+	// bigger than MaxInflightBytes, or MaxIOPS < 1) can never be satisfied in
+	// full - tokens are clamped to b.max every iteration, so b.tokens >= n
+	// would never become true and the caller would block forever. Cap the
+	// request at capacity: the oversized call still drains the bucket
+	// completely (maximum available throttling) and then proceeds, instead
+	// of hanging the xaction.
+	if n > b.max {
+		n = b.max
+	}
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.refillPerSec
+		if b.tokens > b.max {
+			b.tokens = b.max
+		}
+		b.last = now
+		if b.tokens >= n {
+			b.tokens -= n
+			b.mu.Unlock()
+			return
+		}
+		missing := n - b.tokens
+		wait := time.Duration(missing / b.refillPerSec * float64(time.Second))
+		b.mu.Unlock()
+		if wait > 100*time.Millisecond {
+			wait = 100 * time.Millisecond // re-check periodically rather than oversleeping
+		}
+		time.Sleep(wait)
+		throttled += wait
+	}
+}
+
+// joinCgroup writes the calling goroutine's OS thread id into
+// limits.CgroupPath/cgroup.threads so operators can cap the whole xaction
+// with cgroup v2 io/cpu/memory controllers, without moving every other
+// thread of this process into that cgroup too (which writing to
+// cgroup.procs would do). It's a no-op when CgroupPath is unset, and logs
+// (rather than fails the xaction) on error, since cgroup management is host
+// policy, not correctness-critical.
+func (lim *xactLimiter) joinCgroup() {
+	if lim == nil || lim.limits.CgroupPath == "" {
+		return
+	}
+	if err := writeTidToCgroup(lim.limits.CgroupPath); err != nil {
+		glog.Errorf("cgroup %q: %v", lim.limits.CgroupPath, err)
+	}
+}